@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// ==========================
+// API key auth + token-bucket rate limiting
+// ==========================
+
+// apiKeyConfig adalah metadata satu API key, bisa datang dari env API_KEYS
+// (pakai default quota) atau dari file keys.json (per-key custom quota).
+type apiKeyConfig struct {
+	Key        string `json:"key"`
+	Name       string `json:"name"`
+	DailyQuota int    `json:"daily_quota"`
+	RPM        int    `json:"rpm"`
+}
+
+const (
+	defaultDailyQuota = 200
+	defaultRPM        = 10
+)
+
+// apiKeys nyimpen semua key yang valid, key peta = API key string-nya sendiri.
+// Kosong berarti server jalan tanpa autentikasi (bootstrap mode).
+var apiKeys = map[string]apiKeyConfig{}
+
+// loadAPIKeys baca API_KEYS (comma-separated) dan/atau keys.json, digabung
+// jadi satu set. Dipanggil sekali di main() sebelum server jalan.
+func loadAPIKeys() {
+	if raw := os.Getenv("API_KEYS"); raw != "" {
+		for _, key := range strings.Split(raw, ",") {
+			key = strings.TrimSpace(key)
+			if key == "" {
+				continue
+			}
+			apiKeys[key] = apiKeyConfig{Key: key, Name: key, DailyQuota: defaultDailyQuota, RPM: defaultRPM}
+		}
+	}
+
+	path := envOrDefault("API_KEYS_FILE", "keys.json")
+	if data, err := os.ReadFile(path); err == nil {
+		var configs []apiKeyConfig
+		if err := json.Unmarshal(data, &configs); err != nil {
+			log.Println("gagal parse", path, ":", err)
+		} else {
+			for _, cfg := range configs {
+				if cfg.DailyQuota <= 0 {
+					cfg.DailyQuota = defaultDailyQuota
+				}
+				if cfg.RPM <= 0 {
+					cfg.RPM = defaultRPM
+				}
+				apiKeys[cfg.Key] = cfg
+			}
+		}
+	}
+
+	if len(apiKeys) == 0 {
+		log.Println("WARNING: tidak ada API key yang terkonfigurasi (API_KEYS / keys.json kosong), server jalan TANPA autentikasi")
+	}
+}
+
+// keyLimiterState nyimpen token-bucket limiter + pemakaian kuota harian
+// untuk satu API key.
+type keyLimiterState struct {
+	limiter      *rate.Limiter
+	dailyUsed    int
+	dailyResetAt time.Time
+}
+
+var (
+	limiterMu   sync.RWMutex
+	keyLimiters = map[string]*keyLimiterState{}
+)
+
+// limiterFor balikin (atau bikin) limiter state buat satu API key, dan
+// reset kuota harian kalau udah lewat dailyResetAt.
+func limiterFor(cfg apiKeyConfig) *keyLimiterState {
+	limiterMu.Lock()
+	defer limiterMu.Unlock()
+
+	state, ok := keyLimiters[cfg.Key]
+	if !ok {
+		state = &keyLimiterState{
+			limiter:      rate.NewLimiter(rate.Limit(float64(cfg.RPM)/60.0), cfg.RPM),
+			dailyResetAt: nextMidnightUTC(),
+		}
+		keyLimiters[cfg.Key] = state
+	}
+	if time.Now().After(state.dailyResetAt) {
+		state.dailyUsed = 0
+		state.dailyResetAt = nextMidnightUTC()
+	}
+	return state
+}
+
+func nextMidnightUTC() time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+}
+
+// authAndRateLimitMiddleware ngecek X-API-Key, lalu nerapin token-bucket
+// rate limit (per-menit) + kuota harian per key. Kalau gak ada API key yang
+// dikonfigurasi sama sekali, middleware ini jadi no-op (bootstrap mode).
+func authAndRateLimitMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if len(apiKeys) == 0 {
+			c.Next()
+			return
+		}
+
+		key := c.GetHeader("X-API-Key")
+		cfg, ok := apiKeys[key]
+		if key == "" || !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"error":   "missing or invalid X-API-Key",
+			})
+			c.Abort()
+			return
+		}
+
+		state := limiterFor(cfg)
+
+		limiterMu.Lock()
+		if state.dailyUsed >= cfg.DailyQuota {
+			retryAfter := time.Until(state.dailyResetAt)
+			limiterMu.Unlock()
+
+			c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+			c.Header("X-Quota-Remaining", "0")
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"success": false,
+				"error":   "daily quota exceeded",
+			})
+			c.Abort()
+			return
+		}
+		state.dailyUsed++
+		quotaRemaining := cfg.DailyQuota - state.dailyUsed
+		limiterMu.Unlock()
+
+		if !state.limiter.Allow() {
+			limiterMu.Lock()
+			state.dailyUsed-- // request ditolak rate limiter, jangan ikut makan kuota harian
+			limiterMu.Unlock()
+
+			c.Header("Retry-After", "60")
+			c.Header("X-RateLimit-Remaining", "0")
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"success": false,
+				"error":   "rate limit exceeded, coba lagi sebentar lagi",
+			})
+			c.Abort()
+			return
+		}
+
+		resetAt := time.Now().Truncate(time.Minute).Add(time.Minute)
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(int(state.limiter.Tokens())))
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(resetAt.Unix(), 10))
+		c.Header("X-Quota-Remaining", strconv.Itoa(quotaRemaining))
+
+		c.Set("api_key_name", cfg.Name)
+		c.Next()
+	}
+}
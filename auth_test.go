@@ -0,0 +1,77 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(authAndRateLimitMiddleware())
+	r.GET("/ping", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"ok": true}) })
+	return r
+}
+
+func TestAuthMiddlewareOpenWhenNoKeysConfigured(t *testing.T) {
+	apiKeys = map[string]apiKeyConfig{}
+	keyLimiters = map[string]*keyLimiterState{}
+
+	r := newTestRouter()
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 in bootstrap mode, got %d", w.Code)
+	}
+}
+
+func TestAuthMiddlewareRejectsMissingOrInvalidKey(t *testing.T) {
+	apiKeys = map[string]apiKeyConfig{
+		"secret-key": {Key: "secret-key", Name: "test", DailyQuota: defaultDailyQuota, RPM: defaultRPM},
+	}
+	keyLimiters = map[string]*keyLimiterState{}
+
+	r := newTestRouter()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without X-API-Key, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set("X-API-Key", "wrong-key")
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 with wrong X-API-Key, got %d", w.Code)
+	}
+}
+
+func TestAuthMiddlewareRateLimitsAfterBurst(t *testing.T) {
+	apiKeys = map[string]apiKeyConfig{
+		"secret-key": {Key: "secret-key", Name: "test", DailyQuota: defaultDailyQuota, RPM: 2},
+	}
+	keyLimiters = map[string]*keyLimiterState{}
+
+	r := newTestRouter()
+
+	var lastCode int
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.Header.Set("X-API-Key", "secret-key")
+		r.ServeHTTP(w, req)
+		lastCode = w.Code
+	}
+
+	if lastCode != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 after exceeding RPM burst, got %d", lastCode)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ==========================
+// Middleware: CORS
+// ==========================
+
+// corsMiddleware bikin Gin middleware yang ngatur header CORS berdasarkan
+// env ALLOWED_ORIGINS (comma-separated). Default "*" biar gampang dipakai
+// pas development dari frontend origin manapun.
+func corsMiddleware() gin.HandlerFunc {
+	allowedOrigins := parseAllowedOrigins()
+
+	return func(c *gin.Context) {
+		origin := c.Request.Header.Get("Origin")
+		if origin != "" {
+			if isExplicitOrigin(origin, allowedOrigins) {
+				c.Header("Access-Control-Allow-Origin", origin)
+				c.Header("Vary", "Origin")
+				// Credentials cuma valid dipasangin sama origin spesifik, bukan
+				// wildcard (browser bakal nolak kombinasi "*" + credentials).
+				// Auth di sini lewat header X-API-Key, bukan cookie, jadi ini
+				// gak dibutuhin kecuali origin-nya di-whitelist eksplisit -- match
+				// lewat entry "*" gak cukup, walaupun "*" ada di list yang sama.
+				c.Header("Access-Control-Allow-Credentials", "true")
+			} else if isOriginAllowed(origin, allowedOrigins) {
+				c.Header("Access-Control-Allow-Origin", "*")
+			}
+		}
+
+		c.Header("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+		c.Header("Access-Control-Expose-Headers", "X-RateLimit-Remaining, X-RateLimit-Reset, X-Quota-Remaining")
+
+		if c.Request.Method == http.MethodOptions {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// parseAllowedOrigins parse ALLOWED_ORIGINS jadi slice origin, default ke
+// wildcard kalau env-nya kosong.
+func parseAllowedOrigins() []string {
+	raw := envOrDefault("ALLOWED_ORIGINS", "*")
+
+	parts := strings.Split(raw, ",")
+	origins := make([]string, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			origins = append(origins, part)
+		}
+	}
+	if len(origins) == 0 {
+		origins = []string{"*"}
+	}
+	return origins
+}
+
+func isOriginAllowed(origin string, allowed []string) bool {
+	for _, a := range allowed {
+		if a == "*" || a == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// isExplicitOrigin balikin true cuma kalau origin match entry yang bukan
+// wildcard -- dipakai buat mutusin kapan Allow-Credentials boleh dipasang,
+// supaya list campuran semacam "*,https://trusted.example" gak ikut
+// ngasih credentials ke origin manapun gara-gara entry "*"-nya.
+func isExplicitOrigin(origin string, allowed []string) bool {
+	for _, a := range allowed {
+		if a != "*" && a == origin {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,12 @@
+package main
+
+import "os"
+
+// envOrDefault balikin nilai env var kalau di-set, kalau kosong balikin
+// fallback. Dipakai di mana-mana buat konfigurasi per-provider.
+func envOrDefault(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
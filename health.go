@@ -0,0 +1,23 @@
+package main
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// serverStartedAt dicatat pas process start, dipakai buat hitung uptime.
+var serverStartedAt = time.Now()
+
+// ==========================
+// Handler: GET/HEAD /health
+// ==========================
+
+// healthHandler buat liveness probe di belakang load balancer.
+func healthHandler(c *gin.Context) {
+	c.JSON(200, gin.H{
+		"status":   "ok",
+		"provider": defaultProviderName(),
+		"uptime":   time.Since(serverStartedAt).String(),
+	})
+}
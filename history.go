@@ -0,0 +1,250 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	_ "modernc.org/sqlite"
+)
+
+// ==========================
+// History subsystem (opsional, ENABLE_HISTORY=true)
+// ==========================
+
+// historyDB nil kalau history gak diaktifin, jadi semua fungsi di bawah
+// harus cek nil dulu sebelum dipakai.
+var historyDB *sql.DB
+
+// historyEnabled balikin true kalau ENABLE_HISTORY=true di-set.
+func historyEnabled() bool {
+	return strings.EqualFold(os.Getenv("ENABLE_HISTORY"), "true")
+}
+
+// initHistory buka (atau bikin) SQLite db dan migrasi tabel `generations`
+// kalau history diaktifin. No-op kalau ENABLE_HISTORY bukan "true".
+func initHistory() error {
+	if !historyEnabled() {
+		return nil
+	}
+
+	path := envOrDefault("HISTORY_DB_PATH", "./history.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return fmt.Errorf("gagal buka history db: %w", err)
+	}
+
+	schema := `CREATE TABLE IF NOT EXISTS generations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		created_at TEXT NOT NULL,
+		platform TEXT NOT NULL,
+		language TEXT NOT NULL,
+		tone TEXT NOT NULL,
+		description TEXT NOT NULL,
+		variants TEXT NOT NULL,
+		raw_output TEXT NOT NULL,
+		provider TEXT NOT NULL,
+		model TEXT NOT NULL,
+		latency_ms INTEGER NOT NULL,
+		token_usage_json TEXT NOT NULL
+	)`
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return fmt.Errorf("gagal migrasi history db: %w", err)
+	}
+
+	historyDB = db
+	return nil
+}
+
+// generationRecord adalah input buat recordGeneration, diisi dari
+// generateCaptionHandler setelah provider selesai generate.
+type generationRecord struct {
+	Platform    string
+	Language    string
+	Tone        string
+	Description string
+	Variants    []ParsedCaption
+	RawOutput   string
+	Provider    string
+	Model       string
+	LatencyMs   int64
+	Usage       TokenUsage
+}
+
+// recordGeneration nyimpen satu generation ke history db. No-op kalau
+// history gak diaktifin; gagal nyimpen cuma di-log, gak bikin request gagal.
+func recordGeneration(rec generationRecord) {
+	if historyDB == nil {
+		return
+	}
+
+	variantsJSON, err := json.Marshal(rec.Variants)
+	if err != nil {
+		log.Println("gagal marshal variants buat history:", err)
+		return
+	}
+	usageJSON, err := json.Marshal(rec.Usage)
+	if err != nil {
+		log.Println("gagal marshal usage buat history:", err)
+		return
+	}
+
+	_, err = historyDB.Exec(
+		`INSERT INTO generations (created_at, platform, language, tone, description, variants, raw_output, provider, model, latency_ms, token_usage_json)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		time.Now().UTC().Format(time.RFC3339),
+		rec.Platform, rec.Language, rec.Tone, rec.Description,
+		string(variantsJSON), rec.RawOutput, rec.Provider, rec.Model, rec.LatencyMs, string(usageJSON),
+	)
+	if err != nil {
+		log.Println("gagal simpan history generation:", err)
+	}
+}
+
+// generationHistoryItem adalah bentuk satu baris history yang dikirim ke client.
+type generationHistoryItem struct {
+	ID          int64           `json:"id"`
+	CreatedAt   string          `json:"created_at"`
+	Platform    string          `json:"platform"`
+	Language    string          `json:"language"`
+	Tone        string          `json:"tone"`
+	Description string          `json:"description"`
+	Variants    []ParsedCaption `json:"variants"`
+	RawOutput   string          `json:"raw_output"`
+	Provider    string          `json:"provider"`
+	Model       string          `json:"model"`
+	LatencyMs   int64           `json:"latency_ms"`
+	Usage       TokenUsage      `json:"token_usage"`
+}
+
+const historyColumns = "id, created_at, platform, language, tone, description, variants, raw_output, provider, model, latency_ms, token_usage_json"
+
+func scanHistoryRow(scan func(dest ...any) error) (generationHistoryItem, error) {
+	var item generationHistoryItem
+	var variantsJSON, usageJSON string
+
+	if err := scan(&item.ID, &item.CreatedAt, &item.Platform, &item.Language, &item.Tone, &item.Description,
+		&variantsJSON, &item.RawOutput, &item.Provider, &item.Model, &item.LatencyMs, &usageJSON); err != nil {
+		return generationHistoryItem{}, err
+	}
+
+	json.Unmarshal([]byte(variantsJSON), &item.Variants)
+	json.Unmarshal([]byte(usageJSON), &item.Usage)
+	return item, nil
+}
+
+func historyUnavailable(c *gin.Context) bool {
+	if historyDB == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"success": false,
+			"error":   "history belum diaktifkan, set ENABLE_HISTORY=true",
+		})
+		return true
+	}
+	return false
+}
+
+// ==========================
+// Handler: GET /history
+// ==========================
+
+func listHistoryHandler(c *gin.Context) {
+	if historyUnavailable(c) {
+		return
+	}
+
+	limit := 20
+	if raw := c.Query("limit"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 {
+			limit = n
+		}
+	}
+
+	query := "SELECT " + historyColumns + " FROM generations WHERE 1=1"
+	args := []any{}
+
+	if platform := c.Query("platform"); platform != "" {
+		query += " AND platform = ?"
+		args = append(args, platform)
+	}
+	if since := c.Query("since"); since != "" {
+		query += " AND created_at >= ?"
+		args = append(args, since)
+	}
+	query += " ORDER BY id DESC LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := historyDB.Query(query, args...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "error": err.Error()})
+		return
+	}
+	defer rows.Close()
+
+	items := []generationHistoryItem{}
+	for rows.Next() {
+		item, err := scanHistoryRow(rows.Scan)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"success": false, "error": err.Error()})
+			return
+		}
+		items = append(items, item)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "history": items})
+}
+
+// ==========================
+// Handler: GET /history/:id
+// ==========================
+
+func getHistoryHandler(c *gin.Context) {
+	if historyUnavailable(c) {
+		return
+	}
+
+	row := historyDB.QueryRow("SELECT "+historyColumns+" FROM generations WHERE id = ?", c.Param("id"))
+	item, err := scanHistoryRow(row.Scan)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "history not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "history": item})
+}
+
+// ==========================
+// Handler: DELETE /history/:id
+// ==========================
+
+func deleteHistoryHandler(c *gin.Context) {
+	if historyUnavailable(c) {
+		return
+	}
+
+	res, err := historyDB.Exec("DELETE FROM generations WHERE id = ?", c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "error": err.Error()})
+		return
+	}
+
+	affected, _ := res.RowsAffected()
+	if affected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "history not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
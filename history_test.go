@@ -0,0 +1,159 @@
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	_ "modernc.org/sqlite"
+)
+
+// setupTestHistoryDB buka history db in-memory dan migrasi tabelnya, dipakai
+// tiap test biar gak saling ganggu record-nya.
+func setupTestHistoryDB(t *testing.T) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open in-memory sqlite: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	schema := `CREATE TABLE IF NOT EXISTS generations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		created_at TEXT NOT NULL,
+		platform TEXT NOT NULL,
+		language TEXT NOT NULL,
+		tone TEXT NOT NULL,
+		description TEXT NOT NULL,
+		variants TEXT NOT NULL,
+		raw_output TEXT NOT NULL,
+		provider TEXT NOT NULL,
+		model TEXT NOT NULL,
+		latency_ms INTEGER NOT NULL,
+		token_usage_json TEXT NOT NULL
+	)`
+	if _, err := db.Exec(schema); err != nil {
+		t.Fatalf("failed to migrate history db: %v", err)
+	}
+
+	historyDB = db
+	t.Cleanup(func() { historyDB = nil })
+}
+
+func TestRecordGenerationAndListHistory(t *testing.T) {
+	setupTestHistoryDB(t)
+
+	recordGeneration(generationRecord{
+		Platform:    "instagram",
+		Language:    "id",
+		Tone:        "santai",
+		Description: "promo produk",
+		Variants:    []ParsedCaption{{Text: "caption pertama"}},
+		RawOutput:   "raw",
+		Provider:    "openrouter",
+		Model:       "openrouter/auto",
+		LatencyMs:   123,
+		Usage:       TokenUsage{TotalTokens: 42},
+	})
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/history", listHistoryHandler)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/history", nil)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "promo produk") {
+		t.Fatalf("expected response to contain the recorded description, got %s", w.Body.String())
+	}
+}
+
+func TestListHistoryHandlerFiltersByPlatform(t *testing.T) {
+	setupTestHistoryDB(t)
+
+	recordGeneration(generationRecord{Platform: "instagram", Variants: []ParsedCaption{}, Usage: TokenUsage{}})
+	recordGeneration(generationRecord{Platform: "twitter", Variants: []ParsedCaption{}, Usage: TokenUsage{}})
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/history", listHistoryHandler)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/history?platform=twitter", nil)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), `"instagram"`) {
+		t.Fatalf("expected instagram record to be filtered out, got %s", w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"twitter"`) {
+		t.Fatalf("expected twitter record in response, got %s", w.Body.String())
+	}
+}
+
+func TestGetHistoryHandlerNotFound(t *testing.T) {
+	setupTestHistoryDB(t)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/history/:id", getHistoryHandler)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/history/999", nil)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for missing id, got %d", w.Code)
+	}
+}
+
+func TestDeleteHistoryHandlerRemovesRow(t *testing.T) {
+	setupTestHistoryDB(t)
+
+	recordGeneration(generationRecord{Platform: "instagram", Variants: []ParsedCaption{}, Usage: TokenUsage{}})
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.DELETE("/history/:id", deleteHistoryHandler)
+	r.GET("/history/:id", getHistoryHandler)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodDelete, "/history/1", nil)
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 deleting existing id, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/history/1", nil)
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 after delete, got %d", w.Code)
+	}
+}
+
+func TestHistoryHandlersUnavailableWhenDisabled(t *testing.T) {
+	historyDB = nil
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/history", listHistoryHandler)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/history", nil)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 when history is disabled, got %d", w.Code)
+	}
+}
@@ -1,13 +1,11 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -23,6 +21,8 @@ type GenerateCaptionRequest struct {
 	Tone        string `json:"tone" binding:"required"`
 	Description string `json:"description" binding:"required"`
 	Variants    int    `json:"variants"` // optional, default 2
+	Provider    string `json:"provider"` // optional, override CAPTION_PROVIDER
+	Model       string `json:"model"`    // optional, override provider's default model
 }
 
 // ==========================
@@ -35,10 +35,18 @@ type ORMessage struct {
 }
 
 type ORChatRequest struct {
-	Model       string      `json:"model"`
-	Messages    []ORMessage `json:"messages"`
-	Temperature float32     `json:"temperature"`
-	MaxTokens   int         `json:"max_tokens"`
+	Model          string            `json:"model"`
+	Messages       []ORMessage       `json:"messages"`
+	Temperature    float32           `json:"temperature"`
+	MaxTokens      int               `json:"max_tokens"`
+	Stream         bool              `json:"stream,omitempty"`
+	ResponseFormat *ORResponseFormat `json:"response_format,omitempty"`
+}
+
+// ORResponseFormat dipakai buat minta OpenRouter balikin strict JSON
+// (lihat https://openrouter.ai/docs#response-format).
+type ORResponseFormat struct {
+	Type string `json:"type"`
 }
 
 // ==========================
@@ -59,6 +67,29 @@ type ORChatResponse struct {
 
 		Content string `json:"content"`
 	} `json:"choices"`
+
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+// buildPrompt nyusun prompt yang sama buat generateCaptionHandler maupun
+// streamCaptionHandler, biar keduanya selalu minta output shape yang sama
+// (strict JSON sesuai schema orJSONCaptions) dari provider manapun.
+func buildPrompt(req GenerateCaptionRequest) string {
+	return fmt.Sprintf(`
+Buat %d caption %s dalam bahasa %s.
+Deskripsi konten: %s
+Tone: %s.
+Tambahkan hashtag relevan (maksimal 8 hashtag) untuk setiap caption.
+Jangan tambahkan penjelasan lain di luar caption.
+
+Balas HANYA dengan JSON valid, tanpa markdown, persis dengan schema berikut:
+{"captions": [{"text": "isi caption di sini", "hashtags": ["tag1", "tag2"]}]}`,
+		req.Variants, req.Platform, req.Language, req.Description, req.Tone,
+	)
 }
 
 // ==========================
@@ -82,134 +113,49 @@ func generateCaptionHandler(c *gin.Context) {
 		req.Variants = 2
 	}
 
-	// NOTE: tadi di sini kamu pakai `$s` -> harusnya `%s`
-	prompt := fmt.Sprintf(`
-Buat %d caption %s dalam bahasa %s.
-Deskripsi konten: %s
-Tone: %s.
-Setiap caption pisahkan dengan baris baru.
-Tambahkan hashtag relevan (maksimal 8 hashtag).
-Jangan tambahkan penjelasan lain di luar caption.`,
-		req.Variants, req.Platform, req.Language, req.Description, req.Tone,
-	)
+	prompt := buildPrompt(req)
 
-	// call OpenRouter
-	captionText, err := callOpenRouter(prompt)
+	provider, err := resolveProvider(req.Provider, defaultProviderName())
 	if err != nil {
-		log.Println("error callOpenRouter:", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
-			"error":   err.Error(), // sementara kirim error asli biar kelihatan saat dev
+			"error":   err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"success":     true,
-		"caption_raw": captionText,
-	})
-}
-
-// ==========================
-// Fungsi callOpenRouter
-// ==========================
-
-func callOpenRouter(prompt string) (string, error) {
-	apiKey := os.Getenv("OPENROUTER_API_KEY")
-	if apiKey == "" {
-		return "", fmt.Errorf("OPENROUTER_API_KEY tidak di-set, cek file .env")
-	}
-
-	// Payload ke OpenRouter
-	body := ORChatRequest{
-		// Untuk awal, pakai auto dulu biar pasti jalan
-		// nanti kalau mau spesifik bisa ganti lagi
-		Model: "openrouter/auto",
-		Messages: []ORMessage{
-			{
-				Role: "system",
-				Content: "You are an AI assistant specialized in generating high-quality social media captions. " +
-					"You adapt your writing style based on the user's instructions, such as platform, audience, tone, language, and content description. " +
-					"Generate captions that are clear, engaging, and relevant to the context provided. " +
-					"Avoid adding explanations, disclaimers, or content outside the requested captions.",
-			},
-			{
-				Role:    "user",
-				Content: prompt,
-			},
-		},
-		Temperature: 0.9,
-		MaxTokens:   400,
-	}
-
-	jsonBytes, err := json.Marshal(body)
-	if err != nil {
-		return "", err
-	}
-
-	req, err := http.NewRequest(
-		"POST",
-		"https://openrouter.ai/api/v1/chat/completions",
-		bytes.NewBuffer(jsonBytes),
-	)
-	if err != nil {
-		return "", err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+apiKey)
-
-	// optional
-	// req.Header.Set("HTTP-Referer", "https://your-app-domain.example")
-	// req.Header.Set("X-Title", "Caption Generator Service")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	startedAt := time.Now()
+	result, err := provider.Generate(c.Request.Context(), prompt, GenerateOptions{Model: req.Model})
+	latency := time.Since(startedAt)
 	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-
-	bodyBytes, _ := io.ReadAll(resp.Body)
-	log.Println("RAW RESPONSE FROM OPENROUTER:", string(bodyBytes))
-
-	// Kalau status >= 400, langsung balikin error + raw body biar kelihatan
-	if resp.StatusCode >= 400 {
-		return "", fmt.Errorf("OpenRouter error status: %s | body: %s", resp.Status, string(bodyBytes))
-	}
-
-	// Cek dulu: kalau body tidak mulai dengan '{' atau '[',
-	// kemungkinan besar ini bukan JSON (HTML / text jadi).
-	if len(bodyBytes) == 0 {
-		return "", fmt.Errorf("empty response from OpenRouter")
-	}
-	if bodyBytes[0] != '{' && bodyBytes[0] != '[' {
-		return "", fmt.Errorf("non-JSON response from OpenRouter: %s", string(bodyBytes))
-	}
-
-	var orResp ORChatResponse
-	if err := json.Unmarshal(bodyBytes, &orResp); err != nil {
-		return "", fmt.Errorf("failed to parse JSON from OpenRouter: %w | body: %s", err, string(bodyBytes))
-	}
-
-	if len(orResp.Choices) == 0 {
-		return "", fmt.Errorf("no choices returned from OpenRouter | body: %s", string(bodyBytes))
+		log.Println("error", provider.Name(), "Generate:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(), // sementara kirim error asli biar kelihatan saat dev
+		})
+		return
 	}
 
-	choice := orResp.Choices[0]
+	variants := parseCaptions(req.Platform, result.Text)
 
-	// beberapa fallback possible field
-	if choice.Message.Content != "" {
-		return choice.Message.Content, nil
-	}
-	if choice.Delta.Content != "" {
-		return choice.Delta.Content, nil
-	}
-	if choice.Content != "" {
-		return choice.Content, nil
-	}
+	recordGeneration(generationRecord{
+		Platform:    req.Platform,
+		Language:    req.Language,
+		Tone:        req.Tone,
+		Description: req.Description,
+		Variants:    variants,
+		RawOutput:   result.Text,
+		Provider:    provider.Name(),
+		Model:       req.Model,
+		LatencyMs:   latency.Milliseconds(),
+		Usage:       result.Usage,
+	})
 
-	return "", fmt.Errorf("no content field found in OpenRouter response | body: %s", string(bodyBytes))
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"variants": variants,
+		"raw":      result.Text,
+	})
 }
 
 // ==========================
@@ -222,12 +168,27 @@ func main() {
 		log.Println("No .env file found, using system environment variables")
 	}
 
-	// Baru cek dan log API key (hapus di production biar aman)
-	log.Println("OPENROUTER_API_KEY (prefix):", os.Getenv("OPENROUTER_API_KEY")[:5])
+	registerProviders()
+	loadAPIKeys()
 
-	router := gin.Default()
+	if err := initHistory(); err != nil {
+		log.Println("history subsystem disabled:", err)
+	}
 
-	router.POST("/generate-caption", generateCaptionHandler)
+	router := gin.Default()
+	router.Use(corsMiddleware())
+
+	generation := router.Group("/")
+	generation.Use(authAndRateLimitMiddleware())
+	generation.POST("/generate-caption", generateCaptionHandler)
+	generation.POST("/generate-caption/stream", streamCaptionHandler)
+	generation.GET("/history", listHistoryHandler)
+	generation.GET("/history/:id", getHistoryHandler)
+	generation.DELETE("/history/:id", deleteHistoryHandler)
+
+	router.GET("/providers", listProvidersHandler)
+	router.GET("/health", healthHandler)
+	router.HEAD("/health", healthHandler)
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ==========================
+// Struct hasil parsing caption per-variant
+// ==========================
+
+type ParsedCaption struct {
+	Text              string   `json:"text"`
+	Hashtags          []string `json:"hashtags"`
+	CharCount         int      `json:"char_count"`
+	EstimatedReadTime string   `json:"estimated_read_time"`
+	ExceedsLimit      bool     `json:"exceeds_limit"`
+}
+
+// platformCharLimit adalah batas karakter per platform, dipakai buat
+// nandain variant mana yang kepanjangan.
+var platformCharLimit = map[string]int{
+	"twitter":   280,
+	"x":         280,
+	"instagram": 2200,
+	"linkedin":  3000,
+	"tiktok":    150,
+}
+
+var hashtagPattern = regexp.MustCompile(`#\w+`)
+
+// orJSONCaptions adalah bentuk JSON strict yang diminta dari LLM lewat
+// user prompt dari buildPrompt (+ response_format: json_object di provider
+// OpenRouter & OpenAI). systemPrompt sendiri gak nyebut format JSON.
+type orJSONCaptions struct {
+	Captions []struct {
+		Text     string   `json:"text"`
+		Hashtags []string `json:"hashtags"`
+	} `json:"captions"`
+}
+
+// parseCaptions mengubah raw output LLM jadi slice ParsedCaption.
+// Coba parse sebagai JSON strict dulu, kalau gagal baru fallback ke
+// splitter berbasis regex (pisah per blok baris kosong + tarik hashtag).
+func parseCaptions(platform string, raw string) []ParsedCaption {
+	if variants, ok := parseCaptionsJSON(raw); ok && len(variants) > 0 {
+		return finalizeCaptions(platform, variants)
+	}
+	return finalizeCaptions(platform, parseCaptionsFallback(raw))
+}
+
+type rawCaption struct {
+	text     string
+	hashtags []string
+}
+
+func parseCaptionsJSON(raw string) ([]rawCaption, bool) {
+	var parsed orJSONCaptions
+	if err := json.Unmarshal([]byte(strings.TrimSpace(raw)), &parsed); err != nil {
+		return nil, false
+	}
+	if len(parsed.Captions) == 0 {
+		return nil, false
+	}
+
+	variants := make([]rawCaption, 0, len(parsed.Captions))
+	for _, item := range parsed.Captions {
+		variants = append(variants, rawCaption{
+			text:     strings.TrimSpace(item.Text),
+			hashtags: item.Hashtags,
+		})
+	}
+	return variants, true
+}
+
+// parseCaptionsFallback dipakai kalau model gak nurut dan balikin prose
+// biasa alih-alih JSON. Caption dipisah per baris kosong, hashtag ditarik
+// pakai regex dari masing-masing blok.
+func parseCaptionsFallback(raw string) []rawCaption {
+	blocks := regexp.MustCompile(`\n\n+`).Split(strings.TrimSpace(raw), -1)
+
+	variants := make([]rawCaption, 0, len(blocks))
+	for _, block := range blocks {
+		block = strings.TrimSpace(block)
+		if block == "" {
+			continue
+		}
+
+		hashtags := hashtagPattern.FindAllString(block, -1)
+		text := strings.TrimSpace(hashtagPattern.ReplaceAllString(block, ""))
+
+		variants = append(variants, rawCaption{
+			text:     text,
+			hashtags: hashtags,
+		})
+	}
+	return variants
+}
+
+func finalizeCaptions(platform string, variants []rawCaption) []ParsedCaption {
+	limit, hasLimit := platformCharLimit[strings.ToLower(platform)]
+
+	result := make([]ParsedCaption, 0, len(variants))
+	for _, v := range variants {
+		charCount := len([]rune(v.text))
+
+		result = append(result, ParsedCaption{
+			Text:              v.text,
+			Hashtags:          v.hashtags,
+			CharCount:         charCount,
+			EstimatedReadTime: estimateReadTime(v.text),
+			ExceedsLimit:      hasLimit && charCount > limit,
+		})
+	}
+	return result
+}
+
+// estimateReadTime ngira-ngira berapa lama caption ini dibaca, asumsi
+// kecepatan baca rata-rata 200 kata per menit.
+func estimateReadTime(text string) string {
+	words := len(strings.Fields(text))
+	if words == 0 {
+		return "0s"
+	}
+
+	seconds := (words * 60) / 200
+	if seconds < 1 {
+		seconds = 1
+	}
+	return formatSeconds(seconds)
+}
+
+func formatSeconds(seconds int) string {
+	if seconds < 60 {
+		return strconv.Itoa(seconds) + "s"
+	}
+	minutes := seconds / 60
+	return strconv.Itoa(minutes) + "m"
+}
@@ -0,0 +1,70 @@
+package main
+
+import "testing"
+
+func TestParseCaptionsPrefersJSON(t *testing.T) {
+	raw := `{"captions":[{"text":"Liburan ke pantai!","hashtags":["#liburan","#pantai"]},{"text":"Sunset terbaik tahun ini","hashtags":["#sunset"]}]}`
+
+	got := parseCaptions("instagram", raw)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 variants, got %d", len(got))
+	}
+	if got[0].Text != "Liburan ke pantai!" {
+		t.Fatalf("unexpected text: %q", got[0].Text)
+	}
+	if len(got[0].Hashtags) != 2 || got[0].Hashtags[0] != "#liburan" {
+		t.Fatalf("unexpected hashtags: %v", got[0].Hashtags)
+	}
+}
+
+func TestParseCaptionsFallsBackWhenNotJSON(t *testing.T) {
+	raw := "Caption pertama buat konten kuliner #makan #enak\n\nCaption kedua lebih santai #santai"
+
+	got := parseCaptions("twitter", raw)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 variants, got %d", len(got))
+	}
+	if got[0].Text != "Caption pertama buat konten kuliner" {
+		t.Fatalf("expected hashtags stripped from text, got %q", got[0].Text)
+	}
+	if len(got[0].Hashtags) != 2 || got[0].Hashtags[0] != "#makan" {
+		t.Fatalf("unexpected hashtags: %v", got[0].Hashtags)
+	}
+}
+
+func TestParseCaptionsFallsBackOnEmptyCaptionsArray(t *testing.T) {
+	raw := `{"captions":[]}`
+
+	got := parseCaptions("twitter", raw)
+	if len(got) != 1 {
+		t.Fatalf("expected fallback to treat the raw string as one block, got %d variants", len(got))
+	}
+}
+
+func TestFinalizeCaptionsFlagsExceedsLimit(t *testing.T) {
+	short := []rawCaption{{text: "short caption"}}
+	if finalizeCaptions("twitter", short)[0].ExceedsLimit {
+		t.Fatal("short caption should not exceed the twitter limit")
+	}
+
+	long := make([]byte, 300)
+	for i := range long {
+		long[i] = 'a'
+	}
+	tooLong := []rawCaption{{text: string(long)}}
+	if !finalizeCaptions("twitter", tooLong)[0].ExceedsLimit {
+		t.Fatal("300-char caption should exceed the twitter limit of 280")
+	}
+}
+
+func TestFinalizeCaptionsUnknownPlatformNeverExceedsLimit(t *testing.T) {
+	long := make([]byte, 5000)
+	for i := range long {
+		long[i] = 'a'
+	}
+	variants := []rawCaption{{text: string(long)}}
+
+	if finalizeCaptions("unknown-platform", variants)[0].ExceedsLimit {
+		t.Fatal("platform without a known char limit should never be flagged")
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+// ==========================
+// Provider: Anthropic
+// ==========================
+
+type anthropicProvider struct {
+	// baseURL dibiarkan kosong di production (pakai endpoint resmi Anthropic),
+	// di-override pakai httptest server pas unit test.
+	baseURL string
+}
+
+func (p *anthropicProvider) Name() string { return "anthropic" }
+
+func (p *anthropicProvider) endpoint() string {
+	if p.baseURL != "" {
+		return p.baseURL
+	}
+	return "https://api.anthropic.com/v1/messages"
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicRequest struct {
+	Model       string             `json:"model"`
+	System      string             `json:"system"`
+	Messages    []anthropicMessage `json:"messages"`
+	MaxTokens   int                `json:"max_tokens"`
+	Temperature float32            `json:"temperature"`
+}
+
+type anthropicResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	Usage struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (p *anthropicProvider) Generate(ctx context.Context, prompt string, opts GenerateOptions) (GenerateResult, error) {
+	apiKey := os.Getenv("ANTHROPIC_API_KEY")
+	if apiKey == "" {
+		return GenerateResult{}, fmt.Errorf("ANTHROPIC_API_KEY tidak di-set")
+	}
+
+	model := opts.Model
+	if model == "" {
+		model = envOrDefault("ANTHROPIC_MODEL", "claude-3-5-haiku-latest")
+	}
+	maxTokens := opts.MaxTokens
+	if maxTokens == 0 {
+		maxTokens = 400
+	}
+	temperature := opts.Temperature
+	if temperature == 0 {
+		temperature = 0.9
+	}
+
+	body := anthropicRequest{
+		Model:       model,
+		System:      systemPrompt,
+		Messages:    []anthropicMessage{{Role: "user", Content: prompt}},
+		MaxTokens:   maxTokens,
+		Temperature: temperature,
+	}
+
+	jsonBytes, err := json.Marshal(body)
+	if err != nil {
+		return GenerateResult{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.endpoint(), bytes.NewBuffer(jsonBytes))
+	if err != nil {
+		return GenerateResult{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", apiKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return GenerateResult{}, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return GenerateResult{}, err
+	}
+
+	var anResp anthropicResponse
+	if err := json.Unmarshal(bodyBytes, &anResp); err != nil {
+		return GenerateResult{}, fmt.Errorf("failed to parse JSON from Anthropic: %w | body: %s", err, string(bodyBytes))
+	}
+
+	if resp.StatusCode >= 400 {
+		if anResp.Error != nil {
+			return GenerateResult{}, fmt.Errorf("Anthropic error status: %s | message: %s", resp.Status, anResp.Error.Message)
+		}
+		return GenerateResult{}, fmt.Errorf("Anthropic error status: %s | body: %s", resp.Status, string(bodyBytes))
+	}
+
+	usage := TokenUsage{
+		PromptTokens:     anResp.Usage.InputTokens,
+		CompletionTokens: anResp.Usage.OutputTokens,
+		TotalTokens:      anResp.Usage.InputTokens + anResp.Usage.OutputTokens,
+	}
+
+	for _, block := range anResp.Content {
+		if block.Type == "text" && block.Text != "" {
+			return GenerateResult{Text: block.Text, Usage: usage}, nil
+		}
+	}
+
+	return GenerateResult{}, fmt.Errorf("no text content returned from Anthropic | body: %s", string(bodyBytes))
+}
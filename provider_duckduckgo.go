@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ==========================
+// Provider: DuckDuckGo (free chat proxy)
+// ==========================
+
+// duckDuckGoProvider memanfaatkan endpoint chat gratis DuckDuckGo
+// (duckduckgo.com/duckduckgo-ai-chat). Gak butuh API key, tapi gak resmi
+// didokumentasikan sebagai public API, jadi bisa berubah sewaktu-waktu.
+type duckDuckGoProvider struct {
+	// statusURL/chatURL dibiarkan kosong di production (pakai endpoint resmi
+	// DuckDuckGo), di-override pakai httptest server pas unit test.
+	statusURL string
+	chatURL   string
+}
+
+func (p *duckDuckGoProvider) Name() string { return "duckduckgo" }
+
+const (
+	duckDuckGoStatusURL = "https://duckduckgo.com/duckduckgo-ai-chat/status"
+	duckDuckGoChatURL   = "https://duckduckgo.com/duckduckgo-ai-chat/chat"
+)
+
+func (p *duckDuckGoProvider) statusEndpoint() string {
+	if p.statusURL != "" {
+		return p.statusURL
+	}
+	return duckDuckGoStatusURL
+}
+
+func (p *duckDuckGoProvider) chatEndpoint() string {
+	if p.chatURL != "" {
+		return p.chatURL
+	}
+	return duckDuckGoChatURL
+}
+
+type duckDuckGoRequest struct {
+	Model    string      `json:"model"`
+	Messages []ORMessage `json:"messages"`
+}
+
+func (p *duckDuckGoProvider) Generate(ctx context.Context, prompt string, opts GenerateOptions) (GenerateResult, error) {
+	model := opts.Model
+	if model == "" {
+		model = envOrDefault("DUCKDUCKGO_MODEL", "gpt-4o-mini")
+	}
+
+	vqd, err := p.fetchVQD(ctx)
+	if err != nil {
+		return GenerateResult{}, fmt.Errorf("gagal ambil token vqd dari DuckDuckGo: %w", err)
+	}
+
+	body := duckDuckGoRequest{
+		Model: model,
+		Messages: []ORMessage{
+			{Role: "user", Content: prompt},
+		},
+	}
+	jsonBytes, err := json.Marshal(body)
+	if err != nil {
+		return GenerateResult{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.chatEndpoint(), bytes.NewBuffer(jsonBytes))
+	if err != nil {
+		return GenerateResult{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-vqd-4", vqd)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return GenerateResult{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return GenerateResult{}, fmt.Errorf("DuckDuckGo error status: %s", resp.Status)
+	}
+
+	var sb strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		payload := strings.TrimPrefix(line, "data: ")
+		if payload == "[DONE]" {
+			break
+		}
+
+		var chunk struct {
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+			continue
+		}
+		sb.WriteString(chunk.Message)
+	}
+	if err := scanner.Err(); err != nil {
+		return GenerateResult{}, err
+	}
+
+	if sb.Len() == 0 {
+		return GenerateResult{}, fmt.Errorf("no content returned from DuckDuckGo")
+	}
+	// DuckDuckGo gak ngasih info token usage, jadi Usage dibiarkan zero value.
+	return GenerateResult{Text: sb.String()}, nil
+}
+
+// fetchVQD ambil token `x-vqd-4` yang wajib disertakan di tiap request chat.
+func (p *duckDuckGoProvider) fetchVQD(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", p.statusEndpoint(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("x-vqd-accept", "1")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	vqd := resp.Header.Get("x-vqd-4")
+	if vqd == "" {
+		return "", fmt.Errorf("header x-vqd-4 tidak ditemukan di response")
+	}
+	return vqd, nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ==========================
+// Provider: Ollama (local)
+// ==========================
+
+type ollamaProvider struct{}
+
+func (p *ollamaProvider) Name() string { return "ollama" }
+
+type ollamaRequest struct {
+	Model    string      `json:"model"`
+	Messages []ORMessage `json:"messages"`
+	Stream   bool        `json:"stream"`
+}
+
+type ollamaResponse struct {
+	Message struct {
+		Content string `json:"content"`
+	} `json:"message"`
+	PromptEvalCount int    `json:"prompt_eval_count"`
+	EvalCount       int    `json:"eval_count"`
+	Error           string `json:"error"`
+}
+
+func (p *ollamaProvider) Generate(ctx context.Context, prompt string, opts GenerateOptions) (GenerateResult, error) {
+	host := envOrDefault("OLLAMA_HOST", "http://localhost:11434")
+
+	model := opts.Model
+	if model == "" {
+		model = envOrDefault("OLLAMA_MODEL", "llama3")
+	}
+
+	body := ollamaRequest{
+		Model: model,
+		Messages: []ORMessage{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: prompt},
+		},
+		Stream: false,
+	}
+
+	jsonBytes, err := json.Marshal(body)
+	if err != nil {
+		return GenerateResult{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", host+"/api/chat", bytes.NewBuffer(jsonBytes))
+	if err != nil {
+		return GenerateResult{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return GenerateResult{}, fmt.Errorf("gagal menghubungi Ollama di %s: %w", host, err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return GenerateResult{}, err
+	}
+
+	var olResp ollamaResponse
+	if err := json.Unmarshal(bodyBytes, &olResp); err != nil {
+		return GenerateResult{}, fmt.Errorf("failed to parse JSON from Ollama: %w | body: %s", err, string(bodyBytes))
+	}
+
+	if resp.StatusCode >= 400 || olResp.Error != "" {
+		return GenerateResult{}, fmt.Errorf("Ollama error status: %s | message: %s", resp.Status, olResp.Error)
+	}
+	if olResp.Message.Content == "" {
+		return GenerateResult{}, fmt.Errorf("no content returned from Ollama | body: %s", string(bodyBytes))
+	}
+
+	return GenerateResult{
+		Text: olResp.Message.Content,
+		Usage: TokenUsage{
+			PromptTokens:     olResp.PromptEvalCount,
+			CompletionTokens: olResp.EvalCount,
+			TotalTokens:      olResp.PromptEvalCount + olResp.EvalCount,
+		},
+	}, nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+// ==========================
+// Provider: OpenAI
+// ==========================
+
+type openAIProvider struct {
+	// baseURL dibiarkan kosong di production (pakai endpoint resmi OpenAI),
+	// di-override pakai httptest server pas unit test.
+	baseURL string
+}
+
+func (p *openAIProvider) Name() string { return "openai" }
+
+func (p *openAIProvider) endpoint() string {
+	if p.baseURL != "" {
+		return p.baseURL
+	}
+	return "https://api.openai.com/v1/chat/completions"
+}
+
+func (p *openAIProvider) Generate(ctx context.Context, prompt string, opts GenerateOptions) (GenerateResult, error) {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		return GenerateResult{}, fmt.Errorf("OPENAI_API_KEY tidak di-set")
+	}
+
+	model := opts.Model
+	if model == "" {
+		model = envOrDefault("OPENAI_MODEL", "gpt-4o-mini")
+	}
+	maxTokens := opts.MaxTokens
+	if maxTokens == 0 {
+		maxTokens = 400
+	}
+	temperature := opts.Temperature
+	if temperature == 0 {
+		temperature = 0.9
+	}
+
+	body := ORChatRequest{
+		Model: model,
+		Messages: []ORMessage{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: prompt},
+		},
+		Temperature:    temperature,
+		MaxTokens:      maxTokens,
+		ResponseFormat: &ORResponseFormat{Type: "json_object"},
+	}
+
+	jsonBytes, err := json.Marshal(body)
+	if err != nil {
+		return GenerateResult{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.endpoint(), bytes.NewBuffer(jsonBytes))
+	if err != nil {
+		return GenerateResult{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return GenerateResult{}, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return GenerateResult{}, err
+	}
+
+	if resp.StatusCode >= 400 {
+		return GenerateResult{}, fmt.Errorf("OpenAI error status: %s | body: %s", resp.Status, string(bodyBytes))
+	}
+
+	var orResp ORChatResponse
+	if err := json.Unmarshal(bodyBytes, &orResp); err != nil {
+		return GenerateResult{}, fmt.Errorf("failed to parse JSON from OpenAI: %w | body: %s", err, string(bodyBytes))
+	}
+	if len(orResp.Choices) == 0 {
+		return GenerateResult{}, fmt.Errorf("no choices returned from OpenAI | body: %s", string(bodyBytes))
+	}
+
+	return GenerateResult{
+		Text: orResp.Choices[0].Message.Content,
+		Usage: TokenUsage{
+			PromptTokens:     orResp.Usage.PromptTokens,
+			CompletionTokens: orResp.Usage.CompletionTokens,
+			TotalTokens:      orResp.Usage.TotalTokens,
+		},
+	}, nil
+}
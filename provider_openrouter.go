@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// ==========================
+// Provider: OpenRouter (default)
+// ==========================
+
+type openRouterProvider struct {
+	// baseURL dibiarkan kosong di production (pakai endpoint resmi OpenRouter),
+	// di-override pakai httptest server pas unit test.
+	baseURL string
+}
+
+func (p *openRouterProvider) Name() string { return "openrouter" }
+
+func (p *openRouterProvider) endpoint() string {
+	if p.baseURL != "" {
+		return p.baseURL
+	}
+	return "https://openrouter.ai/api/v1/chat/completions"
+}
+
+func (p *openRouterProvider) Generate(ctx context.Context, prompt string, opts GenerateOptions) (GenerateResult, error) {
+	apiKey := os.Getenv("OPENROUTER_API_KEY")
+	if apiKey == "" {
+		return GenerateResult{}, fmt.Errorf("OPENROUTER_API_KEY tidak di-set, cek file .env")
+	}
+
+	model := opts.Model
+	if model == "" {
+		model = "openrouter/auto"
+	}
+	maxTokens := opts.MaxTokens
+	if maxTokens == 0 {
+		maxTokens = 400
+	}
+	temperature := opts.Temperature
+	if temperature == 0 {
+		temperature = 0.9
+	}
+
+	body := ORChatRequest{
+		Model: model,
+		Messages: []ORMessage{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: prompt},
+		},
+		Temperature:    temperature,
+		MaxTokens:      maxTokens,
+		ResponseFormat: &ORResponseFormat{Type: "json_object"},
+	}
+
+	jsonBytes, err := json.Marshal(body)
+	if err != nil {
+		return GenerateResult{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.endpoint(), bytes.NewBuffer(jsonBytes))
+	if err != nil {
+		return GenerateResult{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return GenerateResult{}, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	log.Println("RAW RESPONSE FROM OPENROUTER:", string(bodyBytes))
+
+	if resp.StatusCode >= 400 {
+		return GenerateResult{}, fmt.Errorf("OpenRouter error status: %s | body: %s", resp.Status, string(bodyBytes))
+	}
+	if len(bodyBytes) == 0 {
+		return GenerateResult{}, fmt.Errorf("empty response from OpenRouter")
+	}
+	if bodyBytes[0] != '{' && bodyBytes[0] != '[' {
+		return GenerateResult{}, fmt.Errorf("non-JSON response from OpenRouter: %s", string(bodyBytes))
+	}
+
+	var orResp ORChatResponse
+	if err := json.Unmarshal(bodyBytes, &orResp); err != nil {
+		return GenerateResult{}, fmt.Errorf("failed to parse JSON from OpenRouter: %w | body: %s", err, string(bodyBytes))
+	}
+	if len(orResp.Choices) == 0 {
+		return GenerateResult{}, fmt.Errorf("no choices returned from OpenRouter | body: %s", string(bodyBytes))
+	}
+
+	usage := TokenUsage{
+		PromptTokens:     orResp.Usage.PromptTokens,
+		CompletionTokens: orResp.Usage.CompletionTokens,
+		TotalTokens:      orResp.Usage.TotalTokens,
+	}
+
+	choice := orResp.Choices[0]
+	if choice.Message.Content != "" {
+		return GenerateResult{Text: choice.Message.Content, Usage: usage}, nil
+	}
+	if choice.Delta.Content != "" {
+		return GenerateResult{Text: choice.Delta.Content, Usage: usage}, nil
+	}
+	if choice.Content != "" {
+		return GenerateResult{Text: choice.Content, Usage: usage}, nil
+	}
+
+	return GenerateResult{}, fmt.Errorf("no content field found in OpenRouter response | body: %s", string(bodyBytes))
+}
+
+// GenerateStream implements StreamingCaptionProvider: buka chat completion
+// dengan `"stream": true` dan panggil onDelta tiap kali ada token baru.
+// onDelta balikin false buat stop streaming lebih awal (misalnya client disconnect).
+func (p *openRouterProvider) GenerateStream(ctx context.Context, prompt string, opts GenerateOptions, onDelta func(string) bool) error {
+	apiKey := os.Getenv("OPENROUTER_API_KEY")
+	if apiKey == "" {
+		return fmt.Errorf("OPENROUTER_API_KEY tidak di-set, cek file .env")
+	}
+
+	model := opts.Model
+	if model == "" {
+		model = "openrouter/auto"
+	}
+	maxTokens := opts.MaxTokens
+	if maxTokens == 0 {
+		maxTokens = 400
+	}
+	temperature := opts.Temperature
+	if temperature == 0 {
+		temperature = 0.9
+	}
+
+	body := ORChatRequest{
+		Model: model,
+		Messages: []ORMessage{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: prompt},
+		},
+		Temperature: temperature,
+		MaxTokens:   maxTokens,
+		Stream:      true,
+	}
+
+	jsonBytes, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(
+		ctx,
+		"POST",
+		p.endpoint(),
+		bytes.NewBuffer(jsonBytes),
+	)
+	if err != nil {
+		return err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+apiKey)
+	httpReq.Header.Set("Accept", "text/event-stream")
+
+	client := &http.Client{}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		bodyBytes, _ := bufio.NewReader(resp.Body).Peek(2048)
+		return fmt.Errorf("OpenRouter error status: %s | body: %s", resp.Status, string(bodyBytes))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+
+		payload := strings.TrimPrefix(line, "data: ")
+		if payload == "[DONE]" {
+			break
+		}
+
+		var chunk ORChatResponse
+		if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+			// chunk yang gak valid JSON di-skip aja, jangan sampai stream putus
+			log.Println("skip invalid SSE chunk:", err)
+			continue
+		}
+		if len(chunk.Choices) == 0 {
+			continue
+		}
+
+		delta := chunk.Choices[0].Delta.Content
+		if delta == "" {
+			continue
+		}
+
+		if !onDelta(delta) {
+			break
+		}
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ==========================
+// Abstraksi CaptionProvider
+// ==========================
+
+// GenerateOptions berisi parameter opsional yang bisa di-override per-request,
+// dipakai oleh semua implementasi CaptionProvider.
+type GenerateOptions struct {
+	Model       string
+	Temperature float32
+	MaxTokens   int
+}
+
+// TokenUsage nyimpen jumlah token yang kepake di satu generation, kalau
+// provider-nya ngasih info ini. Provider yang gak expose usage (misalnya
+// DuckDuckGo) cukup balikin zero value.
+type TokenUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// GenerateResult adalah hasil satu panggilan CaptionProvider.Generate.
+type GenerateResult struct {
+	Text  string
+	Usage TokenUsage
+}
+
+// CaptionProvider adalah abstraksi backend LLM yang dipakai untuk generate
+// caption. Setiap provider (OpenRouter, OpenAI, Anthropic, Ollama, dst)
+// implement interface ini supaya gampang di-swap lewat CAPTION_PROVIDER.
+type CaptionProvider interface {
+	Name() string
+	Generate(ctx context.Context, prompt string, opts GenerateOptions) (GenerateResult, error)
+}
+
+// StreamingCaptionProvider adalah CaptionProvider tambahan yang bisa ngirim
+// caption token-by-token. Belum semua provider implement ini — provider yang
+// gak dukung streaming cukup gak implement interface ini, dan caller harus
+// cek lewat type assertion sebelum dipakai.
+type StreamingCaptionProvider interface {
+	CaptionProvider
+	GenerateStream(ctx context.Context, prompt string, opts GenerateOptions, onDelta func(string) bool) error
+}
+
+// systemPrompt adalah instruksi yang sama dipakai di semua provider biar
+// caption yang dihasilkan konsisten style-nya mau lewat backend mana aja.
+const systemPrompt = "You are an AI assistant specialized in generating high-quality social media captions. " +
+	"You adapt your writing style based on the user's instructions, such as platform, audience, tone, language, and content description. " +
+	"Generate captions that are clear, engaging, and relevant to the context provided. " +
+	"Avoid adding explanations, disclaimers, or content outside the requested captions."
+
+// providerRegistry nyimpen semua provider yang available, di-populate di main().
+var providerRegistry = map[string]CaptionProvider{}
+
+// registerProvider daftarin provider ke registry pakai Name()-nya sebagai key.
+func registerProvider(p CaptionProvider) {
+	providerRegistry[p.Name()] = p
+}
+
+// resolveProvider milih provider: override dari body request kalau ada &
+// terdaftar, kalau kosong jatuh ke defaultName (biasanya dari env
+// CAPTION_PROVIDER).
+func resolveProvider(override, defaultName string) (CaptionProvider, error) {
+	name := override
+	if name == "" {
+		name = defaultName
+	}
+	if name == "" {
+		name = "openrouter"
+	}
+
+	p, ok := providerRegistry[name]
+	if !ok {
+		return nil, fmt.Errorf("provider %q tidak terdaftar/tidak dikenal", name)
+	}
+	return p, nil
+}
+
+// ==========================
+// Handler: GET /providers
+// ==========================
+
+func listProvidersHandler(c *gin.Context) {
+	names := make([]string, 0, len(providerRegistry))
+	for name := range providerRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	c.JSON(200, gin.H{
+		"success":   true,
+		"providers": names,
+		"default":   defaultProviderName(),
+	})
+}
+
+// defaultProviderName balikin provider default berdasarkan env CAPTION_PROVIDER.
+func defaultProviderName() string {
+	name := envOrDefault("CAPTION_PROVIDER", "openrouter")
+	return name
+}
+
+// registerProviders daftarin semua implementasi CaptionProvider yang repo ini
+// dukung. Dipanggil sekali di main() sebelum server jalan.
+func registerProviders() {
+	registerProvider(&openRouterProvider{})
+	registerProvider(&openAIProvider{})
+	registerProvider(&anthropicProvider{})
+	registerProvider(&ollamaProvider{})
+	registerProvider(&duckDuckGoProvider{})
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ==========================
+// Handler: generate caption (streaming via SSE)
+// ==========================
+
+func streamCaptionHandler(c *gin.Context) {
+	var req GenerateCaptionRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if req.Variants <= 0 {
+		req.Variants = 2
+	}
+
+	prompt := buildPrompt(req)
+
+	provider, err := resolveProvider(req.Provider, defaultProviderName())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	streamer, ok := provider.(StreamingCaptionProvider)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   fmt.Sprintf("provider %q does not support streaming", provider.Name()),
+		})
+		return
+	}
+
+	// header wajib buat SSE
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // biar nginx gak nge-buffer stream-nya
+
+	flusher, ok := c.Writer.(http.Flusher)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "streaming not supported by this server",
+		})
+		return
+	}
+
+	onDelta := func(delta string) bool {
+		fmt.Fprintf(c.Writer, "data: %s\n\n", mustMarshalSSE(delta))
+		flusher.Flush()
+
+		select {
+		case <-c.Request.Context().Done():
+			// client udah disconnect, stop streaming
+			return false
+		default:
+			return true
+		}
+	}
+
+	if err := streamer.GenerateStream(c.Request.Context(), prompt, GenerateOptions{Model: req.Model}, onDelta); err != nil {
+		log.Println("error", streamer.Name(), "GenerateStream:", err)
+		fmt.Fprintf(c.Writer, "data: %s\n\n", mustMarshalSSE(fmt.Sprintf("[error] %s", err.Error())))
+		flusher.Flush()
+	}
+
+	fmt.Fprint(c.Writer, "data: [DONE]\n\n")
+	flusher.Flush()
+}
+
+// mustMarshalSSE encode satu potongan teks jadi JSON string, biar aman
+// dikirim lewat SSE (newline, quote, dll otomatis di-escape).
+func mustMarshalSSE(s string) string {
+	b, err := json.Marshal(gin.H{"content": s})
+	if err != nil {
+		return `{"content":""}`
+	}
+	return string(b)
+}